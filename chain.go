@@ -3,7 +3,6 @@ package chain
 import (
 	"context"
 	"errors"
-	"fmt"
 	"reflect"
 	"runtime"
 	"strings"
@@ -13,8 +12,8 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-// HandleFunc represents a function that operates on a Chain's Args
-type HandleFunc[I, O any] func(context.Context, *Args[I, O]) error
+// HandleFunc represents a function that operates on a Chain's State
+type HandleFunc[I, O any] func(context.Context, *State[I, O]) error
 
 // Interceptor represents a function that wraps a handleFunc
 type Interceptor[I, O any] func(HandleFunc[I, O]) HandleFunc[I, O]
@@ -22,15 +21,18 @@ type Interceptor[I, O any] func(HandleFunc[I, O]) HandleFunc[I, O]
 // Chain represents a generic operation chain, supporting input type I and output type O
 type Chain[I, O any] struct {
 	ctx           context.Context
-	args          *Args[I, O]
+	args          *State[I, O]
 	fns           []HandleFunc[I, O]
 	interceptors  []Interceptor[I, O]
 	timeout       time.Duration
 	maxGoroutines int
+	dag           map[string]*dagNode[I, O]
+	dagOrder      []string
+	dagRegistered bool
 }
 
-// Args holds the input and output data and a mutex for synchronization
-type Args[I, O any] struct {
+// State holds the input and output data and a mutex for synchronization
+type State[I, O any] struct {
 	input  *I
 	output *O
 	mu     sync.Mutex
@@ -40,7 +42,7 @@ type Args[I, O any] struct {
 func New[I, O any](input *I, output *O) *Chain[I, O] {
 	return &Chain[I, O]{
 		ctx: context.Background(),
-		args: &Args[I, O]{
+		args: &State[I, O]{
 			input:  input,
 			output: output,
 			mu:     sync.Mutex{},
@@ -49,22 +51,30 @@ func New[I, O any](input *I, output *O) *Chain[I, O] {
 }
 
 // Input returns a pointer to the input data of the Chain
-func (c *Args[I, O]) Input() *I {
+func (c *State[I, O]) Input() *I {
 	return c.input
 }
 
 // Output returns a pointer to the output data of the Chain
-func (c *Args[I, O]) Output() *O {
+func (c *State[I, O]) Output() *O {
 	return c.output
 }
 
 // WithLock executes the given function with the Chain's mutex locked
-func (c *Args[I, O]) WithLock(fn func()) {
+func (c *State[I, O]) WithLock(fn func()) {
 	c.mu.Lock()
 	fn()
 	c.mu.Unlock()
 }
 
+// SetOutput mutates the output under the Chain's mutex, allowing concurrent
+// steps (e.g. in Parallel) to safely update shared output fields
+func (c *State[I, O]) SetOutput(fn func(*O)) {
+	c.WithLock(func() {
+		fn(c.output)
+	})
+}
+
 // WithContext sets a custom context for the Chain
 func (c *Chain[I, O]) WithContext(ctx context.Context) *Chain[I, O] {
 	c.ctx = ctx
@@ -83,15 +93,15 @@ func (c *Chain[I, O]) WithMaxGoroutines(max int) *Chain[I, O] {
 	return c
 }
 
-// Use adds an interceptor to the chain
-func (c *Chain[I, O]) Use(interceptor Interceptor[I, O]) *Chain[I, O] {
-	c.interceptors = append(c.interceptors, interceptor)
+// Use adds one or more interceptors to the chain, in the order given
+func (c *Chain[I, O]) Use(interceptors ...Interceptor[I, O]) *Chain[I, O] {
+	c.interceptors = append(c.interceptors, interceptors...)
 	return c
 }
 
 // Serial adds operations to be executed sequentially
 func (c *Chain[I, O]) Serial(fns ...HandleFunc[I, O]) *Chain[I, O] {
-	c.fns = append(c.fns, func(ctx context.Context, args *Args[I, O]) error {
+	c.fns = append(c.fns, func(ctx context.Context, args *State[I, O]) error {
 		for _, fn := range fns {
 			handleFunc := c.buildInterceptors(fn)
 			if err := handleFunc(ctx, c.args); err != nil {
@@ -103,22 +113,44 @@ func (c *Chain[I, O]) Serial(fns ...HandleFunc[I, O]) *Chain[I, O] {
 	return c
 }
 
-// Parallel adds operations to be executed concurrently
+// Parallel adds operations to be executed concurrently. Unlike
+// errgroup.WithContext, one child failing does not cancel ctx for its
+// siblings: every child runs to completion and reports its own error, so
+// the joined error below reflects each child's real cause rather than a
+// sibling's ctx.Err() from being cancelled mid-flight
 func (c *Chain[I, O]) Parallel(fns ...HandleFunc[I, O]) *Chain[I, O] {
-	c.fns = append(c.fns, func(ctx context.Context, args *Args[I, O]) error {
-		g, ctx := errgroup.WithContext(ctx)
+	c.fns = append(c.fns, func(ctx context.Context, args *State[I, O]) error {
+		var g errgroup.Group
 
 		if c.maxGoroutines > 0 {
 			g.SetLimit(c.maxGoroutines)
 		}
+
+		var mu sync.Mutex
+		var errs []error
 		for _, fn := range fns {
 			fn := fn // https://golang.org/doc/faq#closures_and_goroutines
 			g.Go(func() error {
 				handleFunc := c.buildInterceptors(fn)
-				return handleFunc(ctx, c.args)
+				err := handleFunc(ctx, c.args)
+				if err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+				return err
 			})
 		}
-		return g.Wait()
+		_ = g.Wait()
+
+		switch len(errs) {
+		case 0:
+			return nil
+		case 1:
+			return errs[0]
+		default:
+			return &ChainError{Step: "Parallel", Err: errors.Join(errs...)}
+		}
 	})
 	return c
 }
@@ -134,9 +166,8 @@ func (c *Chain[I, O]) Execute() (*O, error) {
 
 	for _, fn := range c.fns {
 		// Execute the chain
-		err := fn(ctx, c.args)
-		if err != nil {
-			return c.args.output, errors.Unwrap(err)
+		if err := fn(ctx, c.args); err != nil {
+			return c.args.output, err
 		}
 	}
 
@@ -145,7 +176,8 @@ func (c *Chain[I, O]) Execute() (*O, error) {
 
 // buildInterceptors wraps the given handleFunc with all interceptors in the chain
 func (c *Chain[I, O]) buildInterceptors(fn HandleFunc[I, O]) HandleFunc[I, O] {
-	handleFunc := func(ctx context.Context, args *Args[I, O]) error {
+	step := getFunctionName(fn)
+	handleFunc := func(ctx context.Context, args *State[I, O]) error {
 		if ctx.Err() != nil {
 			return wrapError(fn, ctx.Err())
 		}
@@ -159,12 +191,40 @@ func (c *Chain[I, O]) buildInterceptors(fn HandleFunc[I, O]) HandleFunc[I, O] {
 	for i := len(c.interceptors) - 1; i >= 0; i-- {
 		handleFunc = c.interceptors[i](handleFunc)
 	}
-	return handleFunc
+	return func(ctx context.Context, args *State[I, O]) error {
+		return handleFunc(contextWithStepName(ctx, step), args)
+	}
 }
 
-// wrapError returns a new error with function name and original error
+// stepNameKey is the context key under which buildInterceptors stashes the
+// real step name, since an interceptor only ever sees the wrapper closures
+// built around it, not the user's fn
+type stepNameKey struct{}
+
+// contextWithStepName returns a copy of ctx carrying the current step's name
+func contextWithStepName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, stepNameKey{}, name)
+}
+
+// StepName returns the name of the step currently executing, as set by the
+// Chain around every interceptor invocation. Interceptors that key
+// per-step state (CircuitBreakerInterceptor, MetricsInterceptor, ...) should
+// call this instead of inspecting the HandleFunc they are wrapping, since
+// that HandleFunc is already a wrapper closure by the time any interceptor
+// sees it. Returns "" if ctx did not come from a Chain
+func StepName(ctx context.Context) string {
+	name, _ := ctx.Value(stepNameKey{}).(string)
+	return name
+}
+
+// wrapError returns a *ChainError recording which step failed, linking to
+// any inner ChainError so the full step path survives nested chains
 func wrapError(fn any, err error) error {
-	return fmt.Errorf("%s: %w", getFunctionName(fn), err)
+	ce := &ChainError{Step: getFunctionName(fn), Err: err}
+	if prev, ok := err.(*ChainError); ok {
+		ce.Prev = prev
+	}
+	return ce
 }
 
 // getFunctionName returns the name of the given function
@@ -0,0 +1,39 @@
+package chain
+
+// ChainError preserves the path of steps traversed by a failing chain,
+// alongside the causal chain that led to it. Prev links to the ChainError
+// produced by the step that was already wrapping the error (e.g. a nested
+// chain's own Execute error), so Error() can render the full
+// "step1: step2: cause" path instead of losing it the way a single
+// fmt.Errorf("%w", ...) layer would
+type ChainError struct {
+	Step string
+	Err  error
+	Prev *ChainError
+}
+
+// Error joins every step in the path with ": ", ending with the root cause
+func (e *ChainError) Error() string {
+	if e.Prev != nil {
+		return e.Step + ": " + e.Prev.Error()
+	}
+	return e.Step + ": " + e.Err.Error()
+}
+
+// Unwrap returns the immediate cause, so errors.Is/As keep working through
+// any number of nested ChainErrors down to the root cause
+func (e *ChainError) Unwrap() error {
+	return e.Err
+}
+
+// Path returns the step names traversed, outermost first. A failing Serial
+// or Parallel step yields a single-element path, e.g. ["validateOrder"];
+// a step that runs a nested Chain and returns its error yields one element
+// per level, e.g. ["outerStep", "innerStep"]
+func (e *ChainError) Path() []string {
+	path := make([]string, 0, 1)
+	for cur := e; cur != nil; cur = cur.Prev {
+		path = append(path, cur.Step)
+	}
+	return path
+}
@@ -0,0 +1,65 @@
+package chain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sysulq/chain-go"
+)
+
+func TestChainErrorPathAndMessage(t *testing.T) {
+	input := &TestInput{Value: 5}
+	output := &TestOutput{}
+	testErr := errors.New("boom")
+
+	c := chain.New(input, output)
+	c.Serial(func(_ context.Context, _ *chain.State[TestInput, TestOutput]) error {
+		return testErr
+	})
+
+	_, err := c.Execute()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	var chainErr *chain.ChainError
+	if !errors.As(err, &chainErr) {
+		t.Fatalf("expected a *chain.ChainError, got %T", err)
+	}
+	if !errors.Is(err, testErr) {
+		t.Errorf("expected errors.Is to find the root cause, got %v", err)
+	}
+
+	path := chainErr.Path()
+	if len(path) != 1 || path[0] == "" {
+		t.Errorf("expected a single-step path, got %v", path)
+	}
+	if err.Error() != path[0]+": "+testErr.Error() {
+		t.Errorf("unexpected error message %q", err.Error())
+	}
+}
+
+func TestChainErrorParallelJoinsCauses(t *testing.T) {
+	input := &TestInput{Value: 5}
+	output := &TestOutput{}
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+
+	c := chain.New(input, output)
+	c.Parallel(
+		func(_ context.Context, _ *chain.State[TestInput, TestOutput]) error { return errA },
+		func(_ context.Context, _ *chain.State[TestInput, TestOutput]) error { return errB },
+	)
+
+	_, err := c.Execute()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !errors.Is(err, errA) {
+		t.Errorf("expected errors.Is to find errA, got %v", err)
+	}
+	if !errors.Is(err, errB) {
+		t.Errorf("expected errors.Is to find errB, got %v", err)
+	}
+}
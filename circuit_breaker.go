@@ -0,0 +1,173 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ErrBreakerOpen is returned when a Breaker rejects a call because its
+// downstream step has been failing too often
+var ErrBreakerOpen = errors.New("circuit breaker is open")
+
+// BreakerOption configures the Breaker used by CircuitBreakerInterceptor
+type BreakerOption func(*breakerConfig)
+
+type breakerConfig struct {
+	window     time.Duration
+	buckets    int
+	k          float64
+	classifier func(error) bool
+}
+
+// WithBreakerWindow sets the size of the rolling window and the number of
+// buckets it is divided into. Defaults to 40 buckets of 250ms each (10s)
+func WithBreakerWindow(window time.Duration, buckets int) BreakerOption {
+	return func(c *breakerConfig) {
+		c.window = window
+		c.buckets = buckets
+	}
+}
+
+// WithBreakerK sets the K factor of the Google SRE adaptive throttling
+// formula: a call is rejected with probability proportional to
+// (requests - K*accepts) / (requests + 1). A higher K tolerates more
+// failures before the breaker starts shedding load. Defaults to 1.5
+func WithBreakerK(k float64) BreakerOption {
+	return func(c *breakerConfig) {
+		c.k = k
+	}
+}
+
+// WithBreakerClassifier sets a function that classifies an error returned by
+// the wrapped HandleFunc as "expected", counting it as an accept rather than
+// a failure that should trip the breaker. Defaults to classifying
+// context.Canceled as expected
+func WithBreakerClassifier(fn func(error) bool) BreakerOption {
+	return func(c *breakerConfig) {
+		c.classifier = fn
+	}
+}
+
+// bucket counts requests and accepts observed during one time slice of the
+// rolling window
+type bucket struct {
+	requests int64
+	accepts  int64
+}
+
+// Breaker implements the Google SRE client-side adaptive throttling
+// algorithm (see https://sre.google/sre-book/handling-overload/#eq2101),
+// tracking requests/accepts over a rolling window of buckets
+type Breaker struct {
+	mu         sync.Mutex
+	bucketSize time.Duration
+	buckets    []bucket
+	head       int
+	lastTick   time.Time
+	k          float64
+	classifier func(error) bool
+}
+
+func newBreaker(cfg breakerConfig) *Breaker {
+	return &Breaker{
+		bucketSize: cfg.window / time.Duration(cfg.buckets),
+		buckets:    make([]bucket, cfg.buckets),
+		k:          cfg.k,
+		classifier: cfg.classifier,
+	}
+}
+
+// advance rotates the ring forward to now, zeroing buckets that have aged
+// out of the window. Callers must hold b.mu
+func (b *Breaker) advance(now time.Time) {
+	if b.lastTick.IsZero() {
+		b.lastTick = now
+		return
+	}
+
+	ticks := int(now.Sub(b.lastTick) / b.bucketSize)
+	if ticks <= 0 {
+		return
+	}
+	if ticks > len(b.buckets) {
+		ticks = len(b.buckets)
+	}
+	for i := 0; i < ticks; i++ {
+		b.head = (b.head + 1) % len(b.buckets)
+		b.buckets[b.head] = bucket{}
+	}
+	b.lastTick = now
+}
+
+// allow decides, based on the current window, whether a new call should be
+// let through
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance(time.Now())
+
+	var requests, accepts int64
+	for _, bk := range b.buckets {
+		requests += bk.requests
+		accepts += bk.accepts
+	}
+
+	ratio := math.Max(0, (float64(requests)-b.k*float64(accepts))/float64(requests+1))
+	if ratio <= 0 {
+		return true
+	}
+	return rand.Float64() >= ratio
+}
+
+// record reports the outcome of a call that was allowed through
+func (b *Breaker) record(accepted bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance(time.Now())
+
+	b.buckets[b.head].requests++
+	if accepted {
+		b.buckets[b.head].accepts++
+	}
+}
+
+// CircuitBreakerInterceptor returns an Interceptor that tracks the outcomes
+// of the wrapped HandleFunc over a rolling time window, per step, and
+// probabilistically rejects calls with ErrBreakerOpen when the downstream is
+// failing. Breakers are keyed by step name (see StepName) in a sync.Map
+// scoped to this interceptor, so one misbehaving step in a Serial/Parallel
+// group trips independently of the others
+func CircuitBreakerInterceptor[I, O any](opts ...BreakerOption) Interceptor[I, O] {
+	cfg := breakerConfig{
+		window:  10 * time.Second,
+		buckets: 40,
+		k:       1.5,
+		classifier: func(err error) bool {
+			return errors.Is(err, context.Canceled)
+		},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var breakers sync.Map
+
+	return func(fn HandleFunc[I, O]) HandleFunc[I, O] {
+		return func(ctx context.Context, args *State[I, O]) error {
+			v, _ := breakers.LoadOrStore(StepName(ctx), newBreaker(cfg))
+			b := v.(*Breaker)
+
+			if !b.allow() {
+				return &ChainError{Step: StepName(ctx), Err: ErrBreakerOpen}
+			}
+
+			err := fn(ctx, args)
+			b.record(err == nil || cfg.classifier(err))
+			return err
+		}
+	}
+}
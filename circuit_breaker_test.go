@@ -0,0 +1,61 @@
+package chain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sysulq/chain-go"
+)
+
+var errBreakerDownstream = errors.New("downstream error")
+
+func breakerFailingStep(_ context.Context, _ *chain.State[TestInput, TestOutput]) error {
+	return errBreakerDownstream
+}
+
+func breakerHealthyStep(_ context.Context, _ *chain.State[TestInput, TestOutput]) error {
+	return nil
+}
+
+func TestCircuitBreakerTripsOnFailures(t *testing.T) {
+	input := &TestInput{Value: 5}
+	output := &TestOutput{}
+
+	c := chain.New(input, output).
+		Use(chain.CircuitBreakerInterceptor[TestInput, TestOutput](
+			chain.WithBreakerWindow(200*time.Millisecond, 4),
+			chain.WithBreakerK(0),
+		))
+
+	c.Serial(breakerFailingStep)
+
+	var lastErr error
+	for i := 0; i < 50; i++ {
+		_, lastErr = c.Execute()
+	}
+
+	if lastErr == nil {
+		t.Fatal("expected an error after repeated failures")
+	}
+	if !errors.Is(lastErr, errBreakerDownstream) && !errors.Is(lastErr, chain.ErrBreakerOpen) {
+		t.Errorf("expected downstream error or ErrBreakerOpen, got %v", lastErr)
+	}
+}
+
+func TestCircuitBreakerAllowsHealthyCalls(t *testing.T) {
+	input := &TestInput{Value: 5}
+	output := &TestOutput{}
+
+	c := chain.New(input, output).
+		Use(chain.CircuitBreakerInterceptor[TestInput, TestOutput]())
+
+	c.Serial(breakerHealthyStep)
+
+	for i := 0; i < 10; i++ {
+		if _, err := c.Execute(); err != nil {
+			t.Errorf("unexpected error on healthy call: %v", err)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ErrMissingDependency is wrapped into the error returned by Execute when a
+// Node declares a dependency on a node that was never registered
+var ErrMissingDependency = errors.New("chain: node depends on an unknown node")
+
+// ErrDependencyCycle is wrapped into the error returned by Execute when the
+// registered nodes form a dependency cycle
+var ErrDependencyCycle = errors.New("chain: dependency cycle detected among DAG nodes")
+
+// dagNode is a single named step of a DAG-based execution plan
+type dagNode[I, O any] struct {
+	name string
+	deps []string
+	fn   HandleFunc[I, O]
+}
+
+// Node registers a named step with explicit dependencies, building a DAG
+// alongside Serial and Parallel. On Execute, nodes run in topological order
+// with up to maxGoroutines running concurrently: a node becomes runnable as
+// soon as every node it depends on has completed without error
+func (c *Chain[I, O]) Node(name string, deps []string, fn HandleFunc[I, O]) *Chain[I, O] {
+	if c.dag == nil {
+		c.dag = make(map[string]*dagNode[I, O])
+	}
+	c.dag[name] = &dagNode[I, O]{name: name, deps: deps, fn: fn}
+	c.dagOrder = append(c.dagOrder, name)
+
+	if !c.dagRegistered {
+		c.dagRegistered = true
+		c.fns = append(c.fns, c.runDAG)
+	}
+
+	return c
+}
+
+// runDAG executes the registered nodes in dependency order, cancelling the
+// remaining nodes as soon as one of them fails
+func (c *Chain[I, O]) runDAG(ctx context.Context, args *State[I, O]) error {
+	order, err := c.topoSort()
+	if err != nil {
+		return err
+	}
+
+	done := make(map[string]chan struct{}, len(order))
+	for _, name := range order {
+		done[name] = make(chan struct{})
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	if c.maxGoroutines > 0 {
+		g.SetLimit(c.maxGoroutines)
+	}
+
+	for _, name := range order {
+		node := c.dag[name]
+		g.Go(func() error {
+			for _, dep := range node.deps {
+				select {
+				case <-done[dep]:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+
+			handleFunc := c.buildInterceptors(node.fn)
+			if err := handleFunc(ctx, args); err != nil {
+				return err
+			}
+			close(done[node.name])
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+// topoSort validates the DAG with Kahn's algorithm, returning the node names
+// in a valid execution order, or an error describing a missing dependency or
+// a cycle
+func (c *Chain[I, O]) topoSort() ([]string, error) {
+	indegree := make(map[string]int, len(c.dagOrder))
+	dependents := make(map[string][]string, len(c.dagOrder))
+
+	for _, name := range c.dagOrder {
+		node := c.dag[name]
+		indegree[name] = len(node.deps)
+		for _, dep := range node.deps {
+			if _, ok := c.dag[dep]; !ok {
+				return nil, fmt.Errorf("%s: depends on unknown node %q: %w", name, dep, ErrMissingDependency)
+			}
+			dependents[dep] = append(dependents[dep], name)
+		}
+	}
+
+	queue := make([]string, 0, len(c.dagOrder))
+	for _, name := range c.dagOrder {
+		if indegree[name] == 0 {
+			queue = append(queue, name)
+		}
+	}
+
+	order := make([]string, 0, len(c.dagOrder))
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		order = append(order, name)
+
+		for _, dependent := range dependents[name] {
+			indegree[dependent]--
+			if indegree[dependent] == 0 {
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
+	if len(order) != len(c.dagOrder) {
+		return nil, fmt.Errorf("chain: %w", ErrDependencyCycle)
+	}
+
+	return order, nil
+}
+
+// DAGPlan describes the computed execution plan for a DAG chain. Levels[i]
+// lists the node names that can run concurrently at step i, once every node
+// in an earlier level has completed
+type DAGPlan struct {
+	Levels [][]string
+	Edges  map[string][]string
+}
+
+// String renders the plan as Graphviz DOT source, useful for visualizing the
+// chain's dependency graph
+func (p *DAGPlan) String() string {
+	var b strings.Builder
+	b.WriteString("digraph chain {\n")
+	for name, deps := range p.Edges {
+		if len(deps) == 0 {
+			fmt.Fprintf(&b, "\t%q;\n", name)
+		}
+		for _, dep := range deps {
+			fmt.Fprintf(&b, "\t%q -> %q;\n", dep, name)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// DAG computes the execution plan for the chain's registered nodes without
+// running them, for debugging or visualization
+func (c *Chain[I, O]) DAG() (*DAGPlan, error) {
+	if _, err := c.topoSort(); err != nil {
+		return nil, err
+	}
+
+	levelOf := make(map[string]int, len(c.dagOrder))
+	for _, name := range c.dagOrder {
+		c.nodeLevel(name, levelOf)
+	}
+
+	var levels [][]string
+	for _, name := range c.dagOrder {
+		l := levelOf[name]
+		for len(levels) <= l {
+			levels = append(levels, nil)
+		}
+		levels[l] = append(levels[l], name)
+	}
+
+	edges := make(map[string][]string, len(c.dag))
+	for name, node := range c.dag {
+		edges[name] = append([]string(nil), node.deps...)
+	}
+
+	return &DAGPlan{Levels: levels, Edges: edges}, nil
+}
+
+// nodeLevel returns the distance of name from the furthest root it depends
+// on, memoizing results in memo. Callers must already have validated the DAG
+// is acyclic
+func (c *Chain[I, O]) nodeLevel(name string, memo map[string]int) int {
+	if l, ok := memo[name]; ok {
+		return l
+	}
+
+	node := c.dag[name]
+	level := 0
+	for _, dep := range node.deps {
+		if l := c.nodeLevel(dep, memo) + 1; l > level {
+			level = l
+		}
+	}
+
+	memo[name] = level
+	return level
+}
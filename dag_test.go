@@ -0,0 +1,107 @@
+package chain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sysulq/chain-go"
+)
+
+func TestDAGRunsInDependencyOrder(t *testing.T) {
+	input := &TestInput{Value: 5}
+	output := &TestOutput{}
+	c := chain.New(input, output)
+
+	c.Node("a", nil, func(_ context.Context, s *chain.State[TestInput, TestOutput]) error {
+		s.SetOutput(func(o *TestOutput) { o.Result += "A" })
+		return nil
+	})
+	c.Node("b", []string{"a"}, func(_ context.Context, s *chain.State[TestInput, TestOutput]) error {
+		s.SetOutput(func(o *TestOutput) { o.Result += "B" })
+		return nil
+	})
+	c.Node("c", []string{"a"}, func(_ context.Context, s *chain.State[TestInput, TestOutput]) error {
+		s.SetOutput(func(o *TestOutput) { o.Result += "C" })
+		return nil
+	})
+
+	result, err := c.Execute()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Result) != 3 || result.Result[0] != 'A' {
+		t.Errorf("expected A to run first followed by B and C in any order, got %q", result.Result)
+	}
+}
+
+func TestDAGDetectsMissingDependency(t *testing.T) {
+	input := &TestInput{Value: 5}
+	output := &TestOutput{}
+	c := chain.New(input, output)
+
+	c.Node("a", []string{"missing"}, func(_ context.Context, s *chain.State[TestInput, TestOutput]) error {
+		return nil
+	})
+
+	if _, err := c.Execute(); err == nil {
+		t.Error("expected an error for a missing dependency")
+	}
+}
+
+func TestDAGDetectsCycle(t *testing.T) {
+	input := &TestInput{Value: 5}
+	output := &TestOutput{}
+	c := chain.New(input, output)
+
+	c.Node("a", []string{"b"}, func(_ context.Context, s *chain.State[TestInput, TestOutput]) error {
+		return nil
+	})
+	c.Node("b", []string{"a"}, func(_ context.Context, s *chain.State[TestInput, TestOutput]) error {
+		return nil
+	})
+
+	if _, err := c.Execute(); err == nil {
+		t.Error("expected an error for a dependency cycle")
+	}
+}
+
+func TestDAGPlan(t *testing.T) {
+	input := &TestInput{Value: 5}
+	output := &TestOutput{}
+	c := chain.New(input, output)
+
+	c.Node("a", nil, func(_ context.Context, s *chain.State[TestInput, TestOutput]) error { return nil })
+	c.Node("b", []string{"a"}, func(_ context.Context, s *chain.State[TestInput, TestOutput]) error { return nil })
+
+	plan, err := c.DAG()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan.Levels) != 2 {
+		t.Errorf("expected 2 levels, got %d", len(plan.Levels))
+	}
+	if plan.String() == "" {
+		t.Error("expected non-empty Graphviz output")
+	}
+}
+
+func TestDAGStopsOnFirstError(t *testing.T) {
+	input := &TestInput{Value: 5}
+	output := &TestOutput{}
+	c := chain.New(input, output)
+
+	testErr := errors.New("boom")
+	c.Node("a", nil, func(_ context.Context, s *chain.State[TestInput, TestOutput]) error {
+		return testErr
+	})
+	c.Node("b", []string{"a"}, func(_ context.Context, s *chain.State[TestInput, TestOutput]) error {
+		t.Error("b should not run after a fails")
+		return nil
+	})
+
+	_, err := c.Execute()
+	if err == nil || !errors.Is(err, testErr) {
+		t.Errorf("expected wrapped boom error, got %v", err)
+	}
+}
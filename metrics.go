@@ -0,0 +1,246 @@
+package chain
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	histBuckets = 128
+	histMin     = time.Microsecond
+	histMax     = 10 * time.Second
+)
+
+// Snapshot summarizes the call volume, error rate, and latency distribution
+// observed for a step over the current rolling window
+type Snapshot struct {
+	QPS     float64
+	ErrRate float64
+	P50     time.Duration
+	P90     time.Duration
+	P99     time.Duration
+}
+
+// MetricsOption configures a Metrics registry
+type MetricsOption func(*Metrics)
+
+// WithMetricsWindow sets the size of the rolling window and the number of
+// buckets it is divided into. Defaults to 10 buckets of 1s each (10s)
+func WithMetricsWindow(window time.Duration, buckets int) MetricsOption {
+	return func(m *Metrics) {
+		m.window = window
+		m.numBuckets = buckets
+	}
+}
+
+// WithMetricsSink sets a function invoked after every call with the step's
+// current snapshot, so callers can push it to Prometheus, slog, etc
+func WithMetricsSink(fn func(step string, snap Snapshot)) MetricsOption {
+	return func(m *Metrics) {
+		m.sink = fn
+	}
+}
+
+// Metrics aggregates per-step call counts, error counts, and a bucketed
+// latency histogram over a rolling window, similar to go-zero's
+// core/collection/rollingwindow. A single Metrics can be shared by a
+// MetricsInterceptor across every step in a chain; each step (identified by
+// StepName) gets its own window
+type Metrics struct {
+	window     time.Duration
+	numBuckets int
+	sink       func(step string, snap Snapshot)
+
+	mu    sync.Mutex
+	steps map[string]*metricsWindow
+}
+
+// NewMetrics creates a Metrics registry ready to back a MetricsInterceptor
+func NewMetrics(opts ...MetricsOption) *Metrics {
+	m := &Metrics{
+		window:     10 * time.Second,
+		numBuckets: 10,
+		steps:      make(map[string]*metricsWindow),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// windowFor returns the rolling window for step, creating it on first use
+func (m *Metrics) windowFor(step string) *metricsWindow {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, ok := m.steps[step]
+	if !ok {
+		w = newMetricsWindow(m.window, m.numBuckets)
+		m.steps[step] = w
+	}
+	return w
+}
+
+// Snapshot aggregates the live buckets for step and interpolates p50/p90/p99
+// from the latency histogram. Returns a zero Snapshot if step has not been
+// observed yet
+func (m *Metrics) Snapshot(step string) Snapshot {
+	m.mu.Lock()
+	w, ok := m.steps[step]
+	m.mu.Unlock()
+	if !ok {
+		return Snapshot{}
+	}
+	return w.snapshot()
+}
+
+// MetricsInterceptor returns an Interceptor that records call count, error
+// count, and latency for each step into m, keyed by step name (see
+// StepName). This complements LogInterceptor, which logs per-call but gives
+// no aggregate view
+func MetricsInterceptor[I, O any](m *Metrics) Interceptor[I, O] {
+	return func(fn HandleFunc[I, O]) HandleFunc[I, O] {
+		return func(ctx context.Context, args *State[I, O]) error {
+			step := StepName(ctx)
+			w := m.windowFor(step)
+
+			start := time.Now()
+			err := fn(ctx, args)
+			w.record(time.Since(start), err != nil)
+
+			if m.sink != nil {
+				m.sink(step, w.snapshot())
+			}
+			return err
+		}
+	}
+}
+
+// metricsBucket holds the counters for one time slice of a metricsWindow
+type metricsBucket struct {
+	count    int64
+	errCount int64
+	hist     [histBuckets]int64
+}
+
+// metricsWindow is a ring of buckets tracking one step's call volume, error
+// rate, and latency distribution over a rolling time range
+type metricsWindow struct {
+	mu         sync.Mutex
+	bucketSize time.Duration
+	buckets    []metricsBucket
+	head       int
+	lastTick   time.Time
+}
+
+func newMetricsWindow(win time.Duration, numBuckets int) *metricsWindow {
+	return &metricsWindow{
+		bucketSize: win / time.Duration(numBuckets),
+		buckets:    make([]metricsBucket, numBuckets),
+	}
+}
+
+// advance rotates the ring forward to now, zeroing buckets that have aged
+// out of the window. Callers must hold w.mu
+func (w *metricsWindow) advance(now time.Time) {
+	if w.lastTick.IsZero() {
+		w.lastTick = now
+		return
+	}
+
+	ticks := int(now.Sub(w.lastTick) / w.bucketSize)
+	if ticks <= 0 {
+		return
+	}
+	if ticks > len(w.buckets) {
+		ticks = len(w.buckets)
+	}
+	for i := 0; i < ticks; i++ {
+		w.head = (w.head + 1) % len(w.buckets)
+		w.buckets[w.head] = metricsBucket{}
+	}
+	w.lastTick = now
+}
+
+func (w *metricsWindow) record(d time.Duration, isErr bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.advance(time.Now())
+
+	b := &w.buckets[w.head]
+	b.count++
+	if isErr {
+		b.errCount++
+	}
+	b.hist[histIndex(d)]++
+}
+
+func (w *metricsWindow) snapshot() Snapshot {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.advance(time.Now())
+
+	var count, errCount int64
+	var hist [histBuckets]int64
+	for _, b := range w.buckets {
+		count += b.count
+		errCount += b.errCount
+		for i, c := range b.hist {
+			hist[i] += c
+		}
+	}
+
+	snap := Snapshot{}
+	if count > 0 {
+		snap.ErrRate = float64(errCount) / float64(count)
+	}
+	if total := w.bucketSize * time.Duration(len(w.buckets)); total > 0 {
+		snap.QPS = float64(count) / total.Seconds()
+	}
+	snap.P50 = quantile(hist[:], count, 0.50)
+	snap.P90 = quantile(hist[:], count, 0.90)
+	snap.P99 = quantile(hist[:], count, 0.99)
+	return snap
+}
+
+// histIndex maps a latency to a bucket index on a logarithmic scale
+// spanning [histMin, histMax], keeping memory bounded regardless of how
+// widely latencies vary
+func histIndex(d time.Duration) int {
+	if d <= histMin {
+		return 0
+	}
+	if d >= histMax {
+		return histBuckets - 1
+	}
+	span := math.Log(float64(histMax) / float64(histMin))
+	pos := math.Log(float64(d)/float64(histMin)) / span * (histBuckets - 1)
+	return int(pos)
+}
+
+// histValue returns the representative latency for a bucket index, the
+// inverse of histIndex
+func histValue(i int) time.Duration {
+	span := math.Log(float64(histMax) / float64(histMin))
+	return time.Duration(float64(histMin) * math.Exp(span*float64(i)/(histBuckets-1)))
+}
+
+// quantile walks the histogram to find the latency at or below which q
+// fraction of observations fall
+func quantile(hist []int64, total int64, q float64) time.Duration {
+	if total == 0 {
+		return 0
+	}
+
+	target := int64(math.Ceil(q * float64(total)))
+	var cum int64
+	for i, c := range hist {
+		cum += c
+		if cum >= target {
+			return histValue(i)
+		}
+	}
+	return histValue(len(hist) - 1)
+}
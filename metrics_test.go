@@ -0,0 +1,75 @@
+package chain_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sysulq/chain-go"
+)
+
+func stepName(fn any) string {
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	parts := strings.Split(name, ".")
+	return parts[len(parts)-1]
+}
+
+func TestMetricsInterceptorRecordsCallsAndErrors(t *testing.T) {
+	input := &TestInput{Value: 5}
+	output := &TestOutput{}
+	m := chain.NewMetrics(chain.WithMetricsWindow(time.Second, 10))
+
+	fn := func(_ context.Context, _ *chain.State[TestInput, TestOutput]) error {
+		return nil
+	}
+
+	c := chain.New(input, output).Use(chain.MetricsInterceptor[TestInput, TestOutput](m))
+	c.Serial(fn)
+
+	if _, err := c.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	snap := m.Snapshot(stepName(fn))
+	if snap.QPS == 0 {
+		t.Errorf("expected the call to be recorded, got %+v", snap)
+	}
+	if snap.ErrRate != 0 {
+		t.Errorf("expected 0 error rate, got %v", snap.ErrRate)
+	}
+}
+
+func TestMetricsInterceptorTracksErrorRate(t *testing.T) {
+	input := &TestInput{Value: 5}
+	output := &TestOutput{}
+	m := chain.NewMetrics()
+
+	testErr := errors.New("boom")
+	failing := func(_ context.Context, _ *chain.State[TestInput, TestOutput]) error {
+		return testErr
+	}
+
+	c := chain.New(input, output).Use(chain.MetricsInterceptor[TestInput, TestOutput](m))
+	c.Serial(failing)
+
+	if _, err := c.Execute(); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	snap := m.Snapshot(stepName(failing))
+	if snap.ErrRate != 1 {
+		t.Errorf("expected error rate 1, got %v", snap.ErrRate)
+	}
+}
+
+func TestMetricsSnapshotUnknownStep(t *testing.T) {
+	m := chain.NewMetrics()
+	snap := m.Snapshot("never-called")
+	if snap.QPS != 0 || snap.ErrRate != 0 {
+		t.Errorf("expected zero snapshot for unknown step, got %+v", snap)
+	}
+}
@@ -0,0 +1,150 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// RetryOption configures a RetryInterceptor
+type RetryOption func(*retryConfig)
+
+type retryConfig struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	retryIf     func(error) bool
+	onRetry     func(attempt int, err error)
+	retryPanics bool
+}
+
+// WithMaxAttempts sets the maximum number of attempts, including the first
+// one. Defaults to 3
+func WithMaxAttempts(n int) RetryOption {
+	return func(c *retryConfig) {
+		c.maxAttempts = n
+	}
+}
+
+// WithBaseDelay sets the base delay used to compute the exponential backoff.
+// Defaults to 100ms
+func WithBaseDelay(d time.Duration) RetryOption {
+	return func(c *retryConfig) {
+		c.baseDelay = d
+	}
+}
+
+// WithMaxDelay caps the computed backoff delay. Defaults to 10s
+func WithMaxDelay(d time.Duration) RetryOption {
+	return func(c *retryConfig) {
+		c.maxDelay = d
+	}
+}
+
+// WithRetryIf sets the function used to decide whether a failed attempt
+// should be retried. Defaults to retrying everything except context errors
+func WithRetryIf(fn func(error) bool) RetryOption {
+	return func(c *retryConfig) {
+		c.retryIf = fn
+	}
+}
+
+// WithOnRetry sets a callback invoked with every intermediate error, right
+// before sleeping for the next attempt. Only the final error is returned by
+// the interceptor, so this is the only way to observe earlier attempts
+func WithOnRetry(fn func(attempt int, err error)) RetryOption {
+	return func(c *retryConfig) {
+		c.onRetry = fn
+	}
+}
+
+// WithRetryPanics makes a panicking HandleFunc count as a retryable attempt
+// instead of propagating, as if it had returned errPanic. Off by default, so
+// a RecoverInterceptor placed around RetryInterceptor keeps working unchanged
+func WithRetryPanics(v bool) RetryOption {
+	return func(c *retryConfig) {
+		c.retryPanics = v
+	}
+}
+
+// RetryInterceptor returns an Interceptor that retries a failing HandleFunc
+// up to WithMaxAttempts times, sleeping between attempts for an exponential
+// backoff with full jitter: delay = rand(0, min(maxDelay, baseDelay<<attempt)).
+// The sleep honors ctx, so it returns early if the surrounding WithTimeout
+// deadline (or a caller cancellation) fires first. Since retries happen
+// per-step inside the interceptor chain, a single flaky step in a Parallel
+// group is retried without restarting its siblings
+func RetryInterceptor[I, O any](opts ...RetryOption) Interceptor[I, O] {
+	cfg := retryConfig{
+		maxAttempts: 3,
+		baseDelay:   100 * time.Millisecond,
+		maxDelay:    10 * time.Second,
+		retryIf: func(err error) bool {
+			return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+		},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return func(fn HandleFunc[I, O]) HandleFunc[I, O] {
+		return func(ctx context.Context, args *State[I, O]) error {
+			var err error
+			for attempt := 0; attempt < cfg.maxAttempts; attempt++ {
+				if attempt > 0 {
+					if waitErr := sleepBackoff(ctx, cfg.baseDelay, cfg.maxDelay, attempt); waitErr != nil {
+						return err
+					}
+				}
+
+				err = callAttempt(ctx, args, fn, cfg.retryPanics)
+				if err == nil {
+					return nil
+				}
+				if !cfg.retryIf(err) {
+					return err
+				}
+				if cfg.onRetry != nil && attempt+1 < cfg.maxAttempts {
+					cfg.onRetry(attempt+1, err)
+				}
+			}
+			return err
+		}
+	}
+}
+
+// callAttempt runs one attempt of fn, optionally recovering a panic into an
+// error wrapping errPanic so it can be retried like any other failure
+func callAttempt[I, O any](ctx context.Context, args *State[I, O], fn HandleFunc[I, O], recoverPanics bool) (err error) {
+	if recoverPanics {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%w: %v", errPanic, r)
+			}
+		}()
+	}
+	return fn(ctx, args)
+}
+
+// sleepBackoff waits for an exponential backoff delay with full jitter
+// before the given attempt, returning early with ctx.Err() if ctx is done
+// first
+func sleepBackoff(ctx context.Context, base, max time.Duration, attempt int) error {
+	delay := base << attempt
+	if delay <= 0 || delay > max {
+		delay = max
+	}
+	delay = time.Duration(rand.Int63n(int64(delay) + 1))
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,121 @@
+package chain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sysulq/chain-go"
+)
+
+func TestRetrySucceedsAfterFailures(t *testing.T) {
+	input := &TestInput{Value: 5}
+	output := &TestOutput{}
+	testErr := errors.New("transient")
+
+	var calls int
+	c := chain.New(input, output).
+		Use(chain.RetryInterceptor[TestInput, TestOutput](
+			chain.WithMaxAttempts(5),
+			chain.WithBaseDelay(time.Millisecond),
+		))
+
+	c.Serial(func(_ context.Context, _ *chain.State[TestInput, TestOutput]) error {
+		calls++
+		if calls < 3 {
+			return testErr
+		}
+		return nil
+	})
+
+	if _, err := c.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryExhaustsAttempts(t *testing.T) {
+	input := &TestInput{Value: 5}
+	output := &TestOutput{}
+	testErr := errors.New("permanent")
+
+	var calls int
+	var retries []int
+	c := chain.New(input, output).
+		Use(chain.RetryInterceptor[TestInput, TestOutput](
+			chain.WithMaxAttempts(3),
+			chain.WithBaseDelay(time.Millisecond),
+			chain.WithOnRetry(func(attempt int, err error) {
+				retries = append(retries, attempt)
+			}),
+		))
+
+	c.Serial(func(_ context.Context, _ *chain.State[TestInput, TestOutput]) error {
+		calls++
+		return testErr
+	})
+
+	_, err := c.Execute()
+	if err == nil || !errors.Is(err, testErr) {
+		t.Errorf("expected wrapped permanent error, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+	if len(retries) != 2 {
+		t.Errorf("expected 2 OnRetry calls, got %d", len(retries))
+	}
+}
+
+func TestRetryDoesNotRetryContextErrors(t *testing.T) {
+	input := &TestInput{Value: 5}
+	output := &TestOutput{}
+
+	var calls int
+	c := chain.New(input, output).
+		Use(chain.RetryInterceptor[TestInput, TestOutput](chain.WithMaxAttempts(5)))
+
+	c.Serial(func(ctx context.Context, _ *chain.State[TestInput, TestOutput]) error {
+		calls++
+		return context.Canceled
+	})
+
+	_, err := c.Execute()
+	if err == nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected no retries for a context error, got %d calls", calls)
+	}
+}
+
+func TestRetryPanics(t *testing.T) {
+	input := &TestInput{Value: 5}
+	output := &TestOutput{}
+
+	var calls int
+	c := chain.New(input, output).
+		Use(chain.RetryInterceptor[TestInput, TestOutput](
+			chain.WithMaxAttempts(3),
+			chain.WithBaseDelay(time.Millisecond),
+			chain.WithRetryPanics(true),
+		))
+
+	c.Serial(func(_ context.Context, _ *chain.State[TestInput, TestOutput]) error {
+		calls++
+		if calls < 2 {
+			panic("boom")
+		}
+		return nil
+	})
+
+	if _, err := c.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected the panic to count as a retryable attempt, got %d calls", calls)
+	}
+}
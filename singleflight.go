@@ -0,0 +1,75 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrSingleflightPanic is the error propagated to waiters when the leader
+// call of a SingleflightInterceptor panics
+var ErrSingleflightPanic = errors.New("panic in singleflight leader")
+
+// call tracks an in-flight (or just-completed) invocation shared by all
+// callers that computed the same key. err is written by the leader before
+// wg.Done and only read by followers after wg.Wait, so no additional
+// locking is needed
+type call struct {
+	wg  sync.WaitGroup
+	err error
+}
+
+// SingleflightInterceptor coalesces concurrent invocations of a HandleFunc
+// that compute the same key, so that only one of them (the leader) actually
+// runs fn; the rest wait for it to finish and reuse its result. All callers
+// in a Chain share the same *O (see State.Output), so the leader's output
+// mutations are already visible to followers once it returns - there is no
+// separate per-follower output to merge into. This is appropriate only for
+// idempotent steps (e.g. Parallel fan-outs that hit the same cache key),
+// since followers never run fn themselves
+func SingleflightInterceptor[I, O any, K comparable](keyFunc func(*State[I, O]) K) Interceptor[I, O] {
+	var mu sync.Mutex
+	// keyed by step name as well, so distinct steps sharing a key never coalesce
+	calls := make(map[struct {
+		step string
+		key  K
+	}]*call)
+
+	return func(fn HandleFunc[I, O]) HandleFunc[I, O] {
+		return func(ctx context.Context, args *State[I, O]) error {
+			key := struct {
+				step string
+				key  K
+			}{StepName(ctx), keyFunc(args)}
+
+			mu.Lock()
+			if c, ok := calls[key]; ok {
+				mu.Unlock()
+				c.wg.Wait()
+				return c.err
+			}
+
+			c := &call{}
+			c.wg.Add(1)
+			calls[key] = c
+			mu.Unlock()
+
+			c.err = func() (err error) {
+				defer func() {
+					if r := recover(); r != nil {
+						err = fmt.Errorf("%w: %v", ErrSingleflightPanic, r)
+					}
+				}()
+				return fn(ctx, args)
+			}()
+
+			mu.Lock()
+			delete(calls, key)
+			mu.Unlock()
+			c.wg.Done()
+
+			return c.err
+		}
+	}
+}
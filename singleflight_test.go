@@ -0,0 +1,46 @@
+package chain_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sysulq/chain-go"
+)
+
+func TestSingleflightCoalescesConcurrentCalls(t *testing.T) {
+	input := &TestInput{Value: 5}
+	output := &TestOutput{}
+
+	var calls int32
+
+	c := chain.New(input, output).WithMaxGoroutines(5).
+		Use(chain.SingleflightInterceptor(
+			func(s *chain.State[TestInput, TestOutput]) int {
+				return s.Input().Value
+			},
+		))
+
+	fn := func(_ context.Context, s *chain.State[TestInput, TestOutput]) error {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(50 * time.Millisecond)
+		s.SetOutput(func(o *TestOutput) {
+			o.Result = "done"
+		})
+		return nil
+	}
+
+	c.Parallel(fn, fn, fn, fn, fn)
+
+	result, err := c.Execute()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Result != "done" {
+		t.Errorf("expected merged result 'done', got %q", result.Result)
+	}
+	if calls != 1 {
+		t.Errorf("expected fn to run exactly once, got %d", calls)
+	}
+}